@@ -0,0 +1,129 @@
+package main
+
+import (
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ScanConcurrencyLimit is the concurrency budget for /api/keys. It's kept
+	// well above ConcurrencyLimit so a client slowly walking the keyspace with
+	// SCAN-style pagination can't starve component requests.
+	ScanConcurrencyLimit = 50
+
+	defaultScanCount = 3000
+	maxScanCount     = 10000
+)
+
+// keyIndex maintains a stable, sorted snapshot of every translation key across
+// all languages, so SCAN-style cursors can be plain offsets into a slice that
+// doesn't reshuffle between pages. It's rebuilt wholesale (never mutated in
+// place) so a snapshot handed to a caller stays stable even if a mutation
+// happens mid-scan.
+type keyIndex struct {
+	mu   sync.RWMutex
+	keys []string
+}
+
+// globalKeyIndex is the process-wide key ordering used by the scan endpoint.
+var globalKeyIndex = newKeyIndex()
+
+func newKeyIndex() *keyIndex {
+	idx := &keyIndex{}
+	idx.Rebuild()
+	return idx
+}
+
+// Rebuild recomputes the sorted, de-duplicated key slice from localizationDB.
+// Call this after any mutation to the translation store.
+func (idx *keyIndex) Rebuild() {
+	seen := make(map[string]struct{})
+	localizationMu.RLock()
+	for _, translations := range localizationDB {
+		for key := range translations {
+			seen[key] = struct{}{}
+		}
+	}
+	localizationMu.RUnlock()
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	idx.mu.Lock()
+	idx.keys = keys
+	idx.mu.Unlock()
+}
+
+// Snapshot returns the current key slice. The slice is replaced, not mutated,
+// on Rebuild, so callers can range over the returned slice without holding
+// the lock.
+func (idx *keyIndex) Snapshot() []string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.keys
+}
+
+// scanKeysEndpoint implements a Redis SCAN-style cursor over the translation
+// keyspace: cursor is an opaque offset into the stable key index, count caps
+// how many matches a single page returns (default defaultScanCount, capped at
+// maxScanCount), match is an optional filepath.Match glob, and lang restricts
+// results to keys that have a translation in that language. next_cursor is
+// "0" once iteration is complete, matching SCAN's end-of-iteration signal.
+func scanKeysEndpoint(c *gin.Context) {
+	cursor, err := strconv.Atoi(c.DefaultQuery("cursor", "0"))
+	if err != nil || cursor < 0 {
+		cursor = 0
+	}
+
+	count, err := strconv.Atoi(c.DefaultQuery("count", strconv.Itoa(defaultScanCount)))
+	if err != nil || count <= 0 {
+		count = defaultScanCount
+	}
+	if count > maxScanCount {
+		count = maxScanCount
+	}
+
+	match := c.Query("match")
+	lang := c.Query("lang")
+
+	keys := globalKeyIndex.Snapshot()
+
+	matched := make([]string, 0, count)
+	i := cursor
+	for i < len(keys) && len(matched) < count {
+		key := keys[i]
+		i++
+
+		if lang != "" {
+			if _, ok := getTranslation(lang, key); !ok {
+				continue
+			}
+		}
+
+		if match != "" {
+			ok, err := filepath.Match(match, key)
+			if err != nil || !ok {
+				continue
+			}
+		}
+
+		matched = append(matched, key)
+	}
+
+	nextCursor := "0"
+	if i < len(keys) {
+		nextCursor = strconv.Itoa(i)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"keys":        matched,
+		"next_cursor": nextCursor,
+	})
+}
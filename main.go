@@ -4,105 +4,140 @@ import (
 	"container/list"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
-	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
 	ConcurrencyLimit = 2
 	CacheMaxSize     = 50
 	CacheTTL         = 10 * time.Minute
-	RedisTTL         = 30 * time.Minute
+	// StaleWindow extends a cache entry's life past CacheTTL: requests in
+	// that window get the stale value immediately plus a background refresh,
+	// instead of every caller blocking on a re-render.
+	StaleWindow = 5 * time.Minute
+	RedisTTL    = 30 * time.Minute
 )
 
-// TTLCache implements a simple LRU cache with TTL
+// cacheFreshness reports how a TTLCache.Get result relates to its fresh/stale
+// windows, so callers can decide whether to serve it as-is, serve it and
+// kick off a background refresh, or treat it as a miss.
+type cacheFreshness int
+
+const (
+	cacheMiss cacheFreshness = iota
+	cacheFresh
+	cacheStale
+)
+
+// TTLCache implements an LRU cache with a two-stage (fresh, then stale)
+// expiry, for stale-while-revalidate: within freshUntil, Get reports
+// cacheFresh; between freshUntil and staleUntil it reports cacheStale so the
+// caller can serve the old value while refreshing in the background; past
+// staleUntil the entry is evicted and Get reports cacheMiss.
 type TTLCache struct {
-	mu         sync.Mutex
-	maxSize    int
-	ttl        time.Duration
-	cache      map[string]*list.Element
-	lruList    *list.List
-	timestamps map[string]time.Time
+	mu      sync.Mutex
+	maxSize int
+	cache   map[string]*list.Element
+	lruList *list.List
 }
 
 type cacheEntry struct {
-	key   string
-	value interface{}
+	key        string
+	value      interface{}
+	freshUntil time.Time
+	staleUntil time.Time
 }
 
-// NewTTLCache creates a new TTL cache
+// NewTTLCache creates a new TTL cache.
 func NewTTLCache(maxSize int, ttl time.Duration) *TTLCache {
 	return &TTLCache{
-		maxSize:    maxSize,
-		ttl:        ttl,
-		cache:      make(map[string]*list.Element),
-		lruList:    list.New(),
-		timestamps: make(map[string]time.Time),
+		maxSize: maxSize,
+		cache:   make(map[string]*list.Element),
+		lruList: list.New(),
 	}
 }
 
-// Get retrieves a value from the cache
-func (c *TTLCache) Get(key string) (interface{}, bool) {
+// Get retrieves a value from the cache along with its freshness.
+func (c *TTLCache) Get(key string) (interface{}, cacheFreshness) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	element, exists := c.cache[key]
 	if !exists {
-		return nil, false
+		return nil, cacheMiss
 	}
+	entry := element.Value.(*cacheEntry)
 
-	// Check TTL
-	timestamp, ok := c.timestamps[key]
-	if !ok || time.Since(timestamp) > c.ttl {
-		// Remove expired item
+	now := time.Now()
+	if now.After(entry.staleUntil) {
 		c.lruList.Remove(element)
 		delete(c.cache, key)
-		delete(c.timestamps, key)
-		return nil, false
+		return nil, cacheMiss
 	}
 
-	// Move to end (most recently used)
 	c.lruList.MoveToBack(element)
-	return element.Value.(*cacheEntry).value, true
+	if now.After(entry.freshUntil) {
+		return entry.value, cacheStale
+	}
+	return entry.value, cacheFresh
 }
 
-// Put adds a value to the cache
-func (c *TTLCache) Put(key string, value interface{}) {
+// Put adds a value to the cache, fresh for freshFor and then servable-but-
+// stale for an additional staleFor.
+func (c *TTLCache) Put(key string, value interface{}, freshFor, staleFor time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	now := time.Now()
+	freshUntil := now.Add(freshFor)
+	staleUntil := freshUntil.Add(staleFor)
+
 	if element, exists := c.cache[key]; exists {
-		// Update existing item
 		c.lruList.MoveToBack(element)
-		element.Value.(*cacheEntry).value = value
-		c.timestamps[key] = time.Now()
+		entry := element.Value.(*cacheEntry)
+		entry.value = value
+		entry.freshUntil = freshUntil
+		entry.staleUntil = staleUntil
 		return
 	}
 
-	// Add new item
 	if c.lruList.Len() >= c.maxSize {
-		// Remove least recently used item
 		oldest := c.lruList.Front()
 		if oldest != nil {
 			entry := oldest.Value.(*cacheEntry)
 			c.lruList.Remove(oldest)
 			delete(c.cache, entry.key)
-			delete(c.timestamps, entry.key)
 		}
 	}
 
-	entry := &cacheEntry{key: key, value: value}
+	entry := &cacheEntry{key: key, value: value, freshUntil: freshUntil, staleUntil: staleUntil}
 	element := c.lruList.PushBack(entry)
 	c.cache[key] = element
-	c.timestamps[key] = time.Now()
+}
+
+// EvictWhere removes every entry for which predicate returns true. It's used
+// to invalidate cache entries affected by a translation mutation.
+func (c *TTLCache) EvictWhere(predicate func(key string, value interface{}) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, element := range c.cache {
+		if predicate(key, element.Value.(*cacheEntry).value) {
+			c.lruList.Remove(element)
+			delete(c.cache, key)
+		}
+	}
 }
 
 // Size returns the current size of the cache
@@ -118,7 +153,6 @@ func (c *TTLCache) Clear() {
 	defer c.mu.Unlock()
 	c.cache = make(map[string]*list.Element)
 	c.lruList = list.New()
-	c.timestamps = make(map[string]time.Time)
 }
 
 // ConcurrencyLimiter middleware to limit concurrent requests
@@ -163,8 +197,16 @@ type LocalizedComponent struct {
 	LocalizedData map[string]string `json:"localized_data"`
 	Metadata      ComponentMetadata `json:"metadata"`
 	Cached        bool              `json:"cached,omitempty"`
+	// UnresolvedKeys lists keys in LocalizedData whose value is still a raw ICU
+	// MessageFormat message (e.g. plural/select) because the params needed to
+	// resolve it weren't supplied. Clients must resolve these themselves.
+	UnresolvedKeys []string `json:"unresolved_keys,omitempty"`
 }
 
+// localizationMu guards localizationDB, which is now mutable via the
+// translations mutation API (PUT/DELETE /api/translations/:lang/:key).
+var localizationMu sync.RWMutex
+
 // Localization database
 var localizationDB = map[string]map[string]string{
 	"en": {
@@ -237,8 +279,77 @@ var localizationDB = map[string]map[string]string{
 	},
 }
 
-// Component templates
-var componentTemplates = map[string]ComponentTemplate{
+// getTranslation looks up a single translation, safe for concurrent use.
+func getTranslation(lang, key string) (string, bool) {
+	localizationMu.RLock()
+	defer localizationMu.RUnlock()
+	translations, ok := localizationDB[lang]
+	if !ok {
+		return "", false
+	}
+	value, ok := translations[key]
+	return value, ok
+}
+
+// resolveLanguage returns lang if it has translations, or "en" otherwise -
+// the same fallback getLocalizedComponent applies when rendering. Callers
+// that need to key a cache entry by the language a component actually ends
+// up rendered in (rather than the language the client asked for) should
+// resolve it up front with this rather than using the raw lang param.
+func resolveLanguage(lang string) string {
+	localizationMu.RLock()
+	defer localizationMu.RUnlock()
+	if _, ok := localizationDB[lang]; ok {
+		return lang
+	}
+	return "en"
+}
+
+// getLanguageTranslations returns a copy of all translations for lang, so
+// callers never hold a reference into the locked map.
+func getLanguageTranslations(lang string) (map[string]string, bool) {
+	localizationMu.RLock()
+	defer localizationMu.RUnlock()
+	translations, ok := localizationDB[lang]
+	if !ok {
+		return nil, false
+	}
+	result := make(map[string]string, len(translations))
+	for k, v := range translations {
+		result[k] = v
+	}
+	return result, true
+}
+
+// setTranslation creates or updates a single translation.
+func setTranslation(lang, key, value string) {
+	localizationMu.Lock()
+	defer localizationMu.Unlock()
+	if _, ok := localizationDB[lang]; !ok {
+		localizationDB[lang] = make(map[string]string)
+	}
+	localizationDB[lang][key] = value
+}
+
+// deleteTranslation removes a single translation, reporting whether it existed.
+func deleteTranslation(lang, key string) bool {
+	localizationMu.Lock()
+	defer localizationMu.Unlock()
+	translations, ok := localizationDB[lang]
+	if !ok {
+		return false
+	}
+	if _, ok := translations[key]; !ok {
+		return false
+	}
+	delete(translations, key)
+	return true
+}
+
+// builtinComponentTemplates are the templates compiled into the binary. They
+// back the default TemplateProvider, used whenever no TEMPLATES_DIR/
+// TEMPLATES_GIT_DIR is configured.
+var builtinComponentTemplates = map[string]ComponentTemplate{
 	"welcome": {
 		ComponentName: "WelcomeComponent",
 		ComponentType: "functional",
@@ -376,6 +487,25 @@ var componentCache = NewTTLCache(CacheMaxSize, CacheTTL)
 var redisClient *redis.Client
 var ctx = context.Background()
 
+// componentRenderGroup coalesces concurrent renders of the same cache key -
+// whether from a cold miss or a background stale-while-revalidate refresh -
+// into a single call to renderAndCacheComponent.
+var componentRenderGroup singleflight.Group
+
+// componentRenderSemaphore bounds how many distinct-key component renders
+// (Redis round-trip + ICU parse/render + JSON marshal) can run at once.
+// componentRenderGroup only coalesces callers that land on the very same
+// cache key - a client varying params (and so the paramsCacheSuffix) on
+// every request still produces one render per distinct key, so this is the
+// backpressure that actually reflects that work, replacing the old flat
+// ConcurrencyLimiter on the whole /api/component route (which would have
+// also blocked cache hits).
+var componentRenderSemaphore = make(chan struct{}, ConcurrencyLimit)
+
+// errServerBusy is returned by renderAndCacheComponent when
+// componentRenderSemaphore has no free slot.
+var errServerBusy = errors.New("server is at capacity, please try again later")
+
 // initRedis initializes the Redis client
 func initRedis() *redis.Client {
 	redisAddr := os.Getenv("REDIS_ADDR")
@@ -417,36 +547,119 @@ func setInRedis(key string, component *LocalizedComponent) error {
 	return redisClient.Set(ctx, key, data, RedisTTL).Err()
 }
 
-// interpolateTemplate replaces {l10n.key} patterns with actual localized values
+// deleteRedisKeysByPattern removes every key matching pattern, walking the
+// keyspace with SCAN/cursor pages instead of KEYS - KEYS is O(keyspace) and
+// blocks the whole server for its duration, which is exactly what cache
+// invalidation (run on every translation mutation and template reload)
+// can't afford to do.
+func deleteRedisKeysByPattern(pattern string) {
+	var cursor uint64
+	for {
+		keys, next, err := redisClient.Scan(ctx, cursor, pattern, defaultScanCount).Result()
+		if err != nil {
+			return
+		}
+		if len(keys) > 0 {
+			redisClient.Del(ctx, keys...)
+		}
+		cursor = next
+		if cursor == 0 {
+			return
+		}
+	}
+}
+
+// interpolateTemplate substitutes {l10n.<key>} placeholders in a JSX template
+// with the corresponding localized string literal. It scans the template
+// directly (a single left-to-right pass over the runes) rather than using
+// regexp, so nested braces inside ICU-resolved values and the placeholder
+// delimiters themselves never get misinterpreted as part of each other.
 func interpolateTemplate(template string, localizedData map[string]string) string {
-	result := template
-	for key, value := range localizedData {
-		pattern := regexp.MustCompile(`\{l10n\.` + regexp.QuoteMeta(key) + `\}`)
-		result = pattern.ReplaceAllString(result, fmt.Sprintf(`"%s"`, value))
+	var sb strings.Builder
+	runes := []rune(template)
+	for i := 0; i < len(runes); {
+		if runes[i] == '{' {
+			if key, end, ok := matchL10nPlaceholder(runes, i); ok {
+				if value, found := localizedData[key]; found {
+					sb.WriteString(fmt.Sprintf(`"%s"`, value))
+					i = end
+					continue
+				}
+			}
+		}
+		sb.WriteRune(runes[i])
+		i++
+	}
+	return sb.String()
+}
+
+// matchL10nPlaceholder reports whether runes[start:] begins with "{l10n.<key>}"
+// and, if so, returns the key name and the index just past the closing brace.
+func matchL10nPlaceholder(runes []rune, start int) (key string, end int, ok bool) {
+	const prefix = "{l10n."
+	if start+len(prefix) > len(runes) || string(runes[start:start+len(prefix)]) != prefix {
+		return "", 0, false
+	}
+	i := start + len(prefix)
+	identStart := i
+	for i < len(runes) && runes[i] != '}' {
+		i++
+	}
+	if i >= len(runes) || i == identStart {
+		return "", 0, false
+	}
+	return string(runes[identStart:i]), i + 1, true
+}
+
+// paramsCacheSuffix builds a stable, deterministic cache-key suffix from a
+// set of ICU message params, so distinct param sets for the same
+// component/lang don't collide in the cache.
+func paramsCacheSuffix(params map[string]interface{}) string {
+	if len(params) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
 	}
-	return result
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, params[k]))
+	}
+	return strings.Join(parts, "&")
 }
 
-// getLocalizedComponent generates a localized React component
-func getLocalizedComponent(componentType, lang string) (*LocalizedComponent, error) {
-	template, exists := componentTemplates[componentType]
+// getLocalizedComponent generates a localized React component. params carries
+// runtime values (e.g. "count") used to resolve ICU plural/select messages;
+// keys whose message can't be fully resolved are left as raw ICU text and
+// reported via the returned component's UnresolvedKeys.
+func getLocalizedComponent(componentType, lang string, params map[string]interface{}) (*LocalizedComponent, error) {
+	template, exists := globalTemplateRegistry.Get(componentType)
 	if !exists {
 		return nil, fmt.Errorf("component type '%s' not found", componentType)
 	}
 
 	// Get localized strings, fallback to English
-	strings, exists := localizationDB[lang]
+	translations, exists := getLanguageTranslations(lang)
 	if !exists {
-		strings = localizationDB["en"]
+		translations, _ = getLanguageTranslations("en")
 	}
 
-	// Get only the required keys for this component
+	// Get only the required keys for this component, resolving any ICU
+	// MessageFormat plural/select syntax against params.
 	componentStrings := make(map[string]string)
+	var unresolvedKeys []string
 	for _, key := range template.RequiredKeys {
-		if value, ok := strings[key]; ok {
-			componentStrings[key] = value
-		} else {
+		raw, ok := translations[key]
+		if !ok {
 			componentStrings[key] = fmt.Sprintf("[%s]", key)
+			continue
+		}
+		value, unresolved := resolveLocalizedValue(raw, lang, params)
+		componentStrings[key] = value
+		if unresolved {
+			unresolvedKeys = append(unresolvedKeys, key)
 		}
 	}
 
@@ -467,6 +680,7 @@ func getLocalizedComponent(componentType, lang string) (*LocalizedComponent, err
 			LastUpdated:  "2024-01-15T10:30:00Z",
 			RequiredKeys: template.RequiredKeys,
 		},
+		UnresolvedKeys: unresolvedKeys,
 	}, nil
 }
 
@@ -489,48 +703,80 @@ func healthCheck(c *gin.Context) {
 	})
 }
 
+// collectMessageParams gathers ICU MessageFormat params from the request:
+// query params (excluding "lang"), overlaid with a JSON body on POST requests.
+func collectMessageParams(c *gin.Context) map[string]interface{} {
+	params := map[string]interface{}{}
+	for key, values := range c.Request.URL.Query() {
+		if key == "lang" || len(values) == 0 {
+			continue
+		}
+		params[key] = values[0]
+	}
+	if c.Request.Method == http.MethodPost && c.Request.ContentLength > 0 {
+		var body map[string]interface{}
+		if err := c.ShouldBindJSON(&body); err == nil {
+			for k, v := range body {
+				params[k] = v
+			}
+		}
+	}
+	return params
+}
+
 // Get localized component handler
 func getLocalizedComponentEndpoint(c *gin.Context) {
 	componentType := c.Param("component_type")
-	lang := c.DefaultQuery("lang", "en")
+	// Resolve to the language the component will actually be rendered in
+	// (getLocalizedComponent falls back to "en" for unsupported languages)
+	// so the cache key - and the Language this renders as - line up with
+	// what evictCachesFor matches translation invalidations against.
+	lang := resolveLanguage(c.DefaultQuery("lang", "en"))
+	params := collectMessageParams(c)
 
 	cacheKey := fmt.Sprintf("component:%s:%s", componentType, lang)
+	if suffix := paramsCacheSuffix(params); suffix != "" {
+		cacheKey = fmt.Sprintf("%s:%s", cacheKey, suffix)
+	}
 
-	// Check TTL cache first
-	if cached, found := componentCache.Get(cacheKey); found {
+	if cached, freshness := componentCache.Get(cacheKey); freshness != cacheMiss {
 		component := cached.(*LocalizedComponent)
-		// refresh the cache
-		componentCache.Put(cacheKey, component)
-		// refresh the redis cache
-		setInRedis(cacheKey, component)
 		response := *component
 		response.Cached = true
+
+		if freshness == cacheStale {
+			componentCacheStaleHits.Inc()
+			// Serve the stale value now; refresh in the background so the
+			// next request (or the next stale window) gets a fresh one.
+			go refreshComponent(cacheKey, componentType, lang, params)
+		} else {
+			componentCacheHits.Inc()
+		}
+
 		c.JSON(http.StatusOK, response)
 		return
 	}
 
-	// TTL cache miss, check Redis
-	if redisClient != nil {
-		component, err := getFromRedis(cacheKey)
-		if err == nil && component != nil {
-			// Found in Redis, store in TTL cache
-			componentCache.Put(cacheKey, component)
-
-			// Refresh Redis TTL
-			setInRedis(cacheKey, component)
-
-			response := *component
-			response.Cached = true
-			c.JSON(http.StatusOK, response)
-			return
-		}
+	// Cache missed entirely: block on singleflight, keyed by cacheKey, so a
+	// thundering herd on a cold key produces exactly one render and one
+	// Redis write regardless of how many requests arrive concurrently.
+	leader := false
+	result, err, shared := componentRenderGroup.Do(cacheKey, func() (interface{}, error) {
+		leader = true
+		return renderAndCacheComponent(cacheKey, componentType, lang, params)
+	})
+	if shared && !leader {
+		componentCoalescedCalls.Inc()
 	}
-
-	// Both caches missed, generate component
-	component, err := getLocalizedComponent(componentType, lang)
 	if err != nil {
-		availableComponents := make([]string, 0, len(componentTemplates))
-		for key := range componentTemplates {
+		if errors.Is(err, errServerBusy) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+		componentRefreshErrors.Inc()
+		registered := globalTemplateRegistry.Templates()
+		availableComponents := make([]string, 0, len(registered))
+		for key := range registered {
 			availableComponents = append(availableComponents, key)
 		}
 		c.JSON(http.StatusNotFound, gin.H{
@@ -540,14 +786,54 @@ func getLocalizedComponentEndpoint(c *gin.Context) {
 		return
 	}
 
-	// Store in both caches
-	componentCache.Put(cacheKey, component)
+	response := *result.(*LocalizedComponent)
+	response.Cached = false
+	c.JSON(http.StatusOK, response)
+}
+
+// renderAndCacheComponent is the singleflight-guarded unit of work behind a
+// cache miss: check Redis, falling back to a fresh render, then populate
+// both caches. Called through componentRenderGroup both on a cold miss and
+// from refreshComponent on a stale hit.
+func renderAndCacheComponent(cacheKey, componentType, lang string, params map[string]interface{}) (interface{}, error) {
+	select {
+	case componentRenderSemaphore <- struct{}{}:
+		defer func() { <-componentRenderSemaphore }()
+	default:
+		return nil, errServerBusy
+	}
+
+	if redisClient != nil {
+		if component, err := getFromRedis(cacheKey); err == nil && component != nil {
+			componentCache.Put(cacheKey, component, CacheTTL, StaleWindow)
+			setInRedis(cacheKey, component)
+			return component, nil
+		}
+	}
+
+	component, err := getLocalizedComponent(componentType, lang, params)
+	if err != nil {
+		return nil, err
+	}
+
+	componentCache.Put(cacheKey, component, CacheTTL, StaleWindow)
 	if redisClient != nil {
 		setInRedis(cacheKey, component)
 	}
+	return component, nil
+}
 
-	component.Cached = false
-	c.JSON(http.StatusOK, component)
+// refreshComponent refreshes cacheKey in the background after a stale cache
+// hit, via renderAndCacheComponent: it takes the Redis copy when one exists
+// and re-renders only when Redis has none. It shares componentRenderGroup with the foreground cold-miss path, so
+// a stale hit racing a concurrent cold miss for the same key still collapses
+// into a single render.
+func refreshComponent(cacheKey, componentType, lang string, params map[string]interface{}) {
+	if _, err, _ := componentRenderGroup.Do(cacheKey, func() (interface{}, error) {
+		return renderAndCacheComponent(cacheKey, componentType, lang, params)
+	}); err != nil {
+		componentRefreshErrors.Inc()
+	}
 }
 
 func main() {
@@ -566,14 +852,59 @@ func main() {
 		fmt.Println("‚úÖ Redis connected successfully")
 	}
 
-	router := gin.Default()
+	// Subscribe to translation invalidations so this replica's caches stay
+	// coherent with mutations made on any other replica.
+	subscribeInvalidations(redisClient)
+
+	// Configure the template provider: filesystem or git-backed if
+	// configured via env, otherwise stick with the built-in templates
+	// compiled into the binary.
+	if gitDir := os.Getenv("TEMPLATES_GIT_DIR"); gitDir != "" {
+		pullInterval := 60 * time.Second
+		if v := os.Getenv("TEMPLATES_GIT_PULL_INTERVAL"); v != "" {
+			if d, err := time.ParseDuration(v); err == nil {
+				pullInterval = d
+			}
+		}
+		if err := globalTemplateRegistry.SetProvider(newGitTemplateProvider(gitDir, pullInterval)); err != nil {
+			fmt.Printf("warning: failed to load templates from git dir %s: %v\n", gitDir, err)
+		}
+	} else if templatesDir := os.Getenv("TEMPLATES_DIR"); templatesDir != "" {
+		if err := globalTemplateRegistry.SetProvider(newFilesystemTemplateProvider(templatesDir)); err != nil {
+			fmt.Printf("warning: failed to load templates from %s: %v\n", templatesDir, err)
+		}
+	} else if err := globalTemplateRegistry.Reload(); err != nil {
+		fmt.Printf("warning: failed to load built-in templates: %v\n", err)
+	}
+	globalTemplateRegistry.StartWatching()
 
-	// Apply concurrency limiter middleware
-	router.Use(ConcurrencyLimiter(ConcurrencyLimit))
+	router := gin.Default()
 
-	// Routes
 	router.GET("/health", healthCheck)
+	router.GET("/api/templates", listTemplatesEndpoint)
+	router.POST("/api/templates/reload", reloadTemplatesEndpoint)
+	router.GET("/ws", wsEndpoint)
+
+	// Component rendering doesn't need the route-level ConcurrencyLimiter:
+	// stale-while-revalidate means most requests are served straight from the
+	// cache without ever reaching getLocalizedComponent, and the renders that
+	// do happen are bounded by componentRenderSemaphore instead - gating the
+	// actual render work rather than cache hits too.
 	router.GET("/api/component/:component_type", getLocalizedComponentEndpoint)
+	router.POST("/api/component/:component_type", getLocalizedComponentEndpoint)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Translation mutations stay behind the tight concurrency limiter: each
+	// one rebuilds the key index and fans out an invalidation, so unlike
+	// component rendering there's no coalescing to absorb a burst.
+	translations := router.Group("/")
+	translations.Use(ConcurrencyLimiter(ConcurrencyLimit))
+	translations.PUT("/api/translations/:lang/:key", putTranslationEndpoint)
+	translations.DELETE("/api/translations/:lang/:key", deleteTranslationEndpoint)
+
+	// Key scanning gets its own, much larger budget so a client slowly paging
+	// through the keyspace can't starve component requests.
+	router.GET("/api/keys", ConcurrencyLimiter(ScanConcurrencyLimit), scanKeysEndpoint)
 
 	// Start server
 	fmt.Println("üöÄ Localization Manager Backend starting on :8000")
@@ -587,8 +918,9 @@ func main() {
 
 // Helper function to get component keys
 func getComponentKeys() []string {
-	keys := make([]string, 0, len(componentTemplates))
-	for key := range componentTemplates {
+	registered := globalTemplateRegistry.Templates()
+	keys := make([]string, 0, len(registered))
+	for key := range registered {
 		keys = append(keys, key)
 	}
 	return keys
@@ -596,6 +928,8 @@ func getComponentKeys() []string {
 
 // Helper function to get language keys
 func getLanguageKeys() []string {
+	localizationMu.RLock()
+	defer localizationMu.RUnlock()
 	keys := make([]string, 0, len(localizationDB))
 	for key := range localizationDB {
 		keys = append(keys, key)
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCacheFreshnessTransitions(t *testing.T) {
+	cache := NewTTLCache(10, 0)
+	cache.Put("k", "v", 20*time.Millisecond, 40*time.Millisecond)
+
+	if value, freshness := cache.Get("k"); freshness != cacheFresh || value != "v" {
+		t.Fatalf("immediately after Put: got (%v, %v), want (v, cacheFresh)", value, freshness)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if value, freshness := cache.Get("k"); freshness != cacheStale || value != "v" {
+		t.Fatalf("after freshFor elapses: got (%v, %v), want (v, cacheStale)", value, freshness)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if _, freshness := cache.Get("k"); freshness != cacheMiss {
+		t.Fatalf("after staleFor elapses: got freshness %v, want cacheMiss", freshness)
+	}
+}
+
+func TestTTLCacheEvictsOnExpiry(t *testing.T) {
+	cache := NewTTLCache(10, 0)
+	cache.Put("k", "v", 10*time.Millisecond, 10*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+
+	if _, freshness := cache.Get("k"); freshness != cacheMiss {
+		t.Fatalf("expected cacheMiss after both windows elapse, got %v", freshness)
+	}
+	if size := cache.Size(); size != 0 {
+		t.Fatalf("expired entry should have been evicted, cache size = %d", size)
+	}
+}
+
+func TestTTLCacheEvictWhere(t *testing.T) {
+	cache := NewTTLCache(10, 0)
+	cache.Put("keep", "a", time.Minute, time.Minute)
+	cache.Put("drop", "b", time.Minute, time.Minute)
+
+	cache.EvictWhere(func(key string, _ interface{}) bool {
+		return key == "drop"
+	})
+
+	if _, freshness := cache.Get("drop"); freshness != cacheMiss {
+		t.Error("EvictWhere should have removed the matching entry")
+	}
+	if _, freshness := cache.Get("keep"); freshness != cacheFresh {
+		t.Error("EvictWhere should not have touched the non-matching entry")
+	}
+}
+
+func TestTTLCacheLRUEviction(t *testing.T) {
+	cache := NewTTLCache(2, 0)
+	cache.Put("a", 1, time.Minute, time.Minute)
+	cache.Put("b", 2, time.Minute, time.Minute)
+	cache.Put("c", 3, time.Minute, time.Minute)
+
+	if _, freshness := cache.Get("a"); freshness != cacheMiss {
+		t.Error("oldest entry should have been evicted once maxSize was exceeded")
+	}
+	if _, freshness := cache.Get("b"); freshness != cacheFresh {
+		t.Error("b should still be cached")
+	}
+	if _, freshness := cache.Get("c"); freshness != cacheFresh {
+		t.Error("c should still be cached")
+	}
+}
@@ -0,0 +1,468 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Node is a single element of a parsed ICU MessageFormat message.
+type Node interface {
+	isNode()
+}
+
+// TextNode is a literal run of text.
+type TextNode struct {
+	Text string
+}
+
+func (TextNode) isNode() {}
+
+// ArgNode is a plain {var} interpolation.
+type ArgNode struct {
+	Name string
+}
+
+func (ArgNode) isNode() {}
+
+// PluralNode is a {var, plural, ...} (or {var, selectordinal, ...}) construct.
+// Options are keyed by CLDR category ("one", "other", ...) or exact-match
+// literals ("=0", "=1") which always take priority over the category.
+type PluralNode struct {
+	Name    string
+	Offset  int
+	Options map[string]Message
+}
+
+func (PluralNode) isNode() {}
+
+// SelectNode is a {var, select, ...} construct.
+type SelectNode struct {
+	Name    string
+	Options map[string]Message
+}
+
+func (SelectNode) isNode() {}
+
+// Message is a parsed sequence of ICU MessageFormat nodes.
+type Message []Node
+
+// RequiredVars returns the set of argument names referenced anywhere in the
+// message, in first-seen order.
+func (m Message) RequiredVars() []string {
+	seen := map[string]bool{}
+	var vars []string
+	var walk func(nodes []Node)
+	walk = func(nodes []Node) {
+		for _, n := range nodes {
+			switch v := n.(type) {
+			case ArgNode:
+				if !seen[v.Name] {
+					seen[v.Name] = true
+					vars = append(vars, v.Name)
+				}
+			case PluralNode:
+				if !seen[v.Name] {
+					seen[v.Name] = true
+					vars = append(vars, v.Name)
+				}
+				for _, sub := range v.Options {
+					walk(sub)
+				}
+			case SelectNode:
+				if !seen[v.Name] {
+					seen[v.Name] = true
+					vars = append(vars, v.Name)
+				}
+				for _, sub := range v.Options {
+					walk(sub)
+				}
+			}
+		}
+	}
+	walk(m)
+	return vars
+}
+
+// messageParser is a small recursive-descent parser for the subset of ICU
+// MessageFormat this service supports: plain text, {var}, {var, plural, ...}
+// and {var, select, ...}, with doubled-quote-style escaping of the brace and
+// quote characters themselves.
+type messageParser struct {
+	input []rune
+	pos   int
+}
+
+// ParseMessage parses src as an ICU MessageFormat message.
+func ParseMessage(src string) (Message, error) {
+	p := &messageParser{input: []rune(src)}
+	nodes, err := p.parseNodes(false)
+	if err != nil {
+		return nil, err
+	}
+	return Message(nodes), nil
+}
+
+func (p *messageParser) peek() (rune, bool) {
+	if p.pos >= len(p.input) {
+		return 0, false
+	}
+	return p.input[p.pos], true
+}
+
+func (p *messageParser) skipSpace() {
+	for {
+		ch, ok := p.peek()
+		if !ok || !isMessageSpace(ch) {
+			return
+		}
+		p.pos++
+	}
+}
+
+func isMessageSpace(ch rune) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
+
+func (p *messageParser) hasPrefix(s string) bool {
+	runes := []rune(s)
+	if p.pos+len(runes) > len(p.input) {
+		return false
+	}
+	return string(p.input[p.pos:p.pos+len(runes)]) == s
+}
+
+// parseToken reads an identifier/number token up to the next space, ',' or '}'.
+func (p *messageParser) parseToken() string {
+	start := p.pos
+	for {
+		ch, ok := p.peek()
+		if !ok || isMessageSpace(ch) || ch == ',' || ch == '}' {
+			break
+		}
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+// readQuotedLiteral consumes a '{literal text}'-style escaped run into buf.
+// Called right after an opening quote has already been consumed; it reads
+// verbatim (including braces) up to the next quote, consuming that closing
+// quote, or to the end of the message if there is none. A doubled quote
+// inside the run is still just a literal quote rather than the close.
+func (p *messageParser) readQuotedLiteral(buf *strings.Builder) {
+	for {
+		ch, ok := p.peek()
+		if !ok {
+			return
+		}
+		p.pos++
+		if ch != '\'' {
+			buf.WriteRune(ch)
+			continue
+		}
+		if next, ok := p.peek(); ok && next == '\'' {
+			buf.WriteRune('\'')
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+// parseNodes parses a run of text/args. When insideArg is true, parsing stops
+// at (and consumes) the matching '}'.
+func (p *messageParser) parseNodes(insideArg bool) ([]Node, error) {
+	var nodes []Node
+	var textBuf strings.Builder
+	flush := func() {
+		if textBuf.Len() > 0 {
+			nodes = append(nodes, TextNode{Text: textBuf.String()})
+			textBuf.Reset()
+		}
+	}
+	for {
+		ch, ok := p.peek()
+		if !ok {
+			break
+		}
+		switch ch {
+		case '\'':
+			p.pos++
+			if next, ok := p.peek(); ok && next == '\'' {
+				textBuf.WriteRune('\'')
+				p.pos++
+				break
+			}
+			// An unpaired quote opens a literal-text run that continues (braces
+			// and all) up to the next quote, or to the end of the message if
+			// there isn't one - the standard ICU '{literal text}' escaping
+			// idiom. A doubled quote inside the run is still a literal quote,
+			// not the closing delimiter.
+			p.readQuotedLiteral(&textBuf)
+		case '{':
+			flush()
+			node, err := p.parseArg()
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		case '}':
+			if insideArg {
+				p.pos++
+				flush()
+				return nodes, nil
+			}
+			return nil, fmt.Errorf("messageformat: unexpected '}' at position %d", p.pos)
+		default:
+			textBuf.WriteRune(ch)
+			p.pos++
+		}
+	}
+	if insideArg {
+		return nil, fmt.Errorf("messageformat: unterminated argument")
+	}
+	flush()
+	return nodes, nil
+}
+
+func (p *messageParser) parseArg() (Node, error) {
+	p.pos++ // consume '{'
+	p.skipSpace()
+	name := p.parseToken()
+	if name == "" {
+		return nil, fmt.Errorf("messageformat: empty argument name at position %d", p.pos)
+	}
+	p.skipSpace()
+	ch, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("messageformat: unterminated argument %q", name)
+	}
+	if ch == '}' {
+		p.pos++
+		return ArgNode{Name: name}, nil
+	}
+	if ch != ',' {
+		return nil, fmt.Errorf("messageformat: expected ',' after %q, got %q", name, ch)
+	}
+	p.pos++
+	p.skipSpace()
+	kind := p.parseToken()
+	p.skipSpace()
+	if ch, ok := p.peek(); !ok || ch != ',' {
+		return nil, fmt.Errorf("messageformat: expected ',' after argument type %q", kind)
+	}
+	p.pos++ // consume ','
+	switch kind {
+	case "plural", "selectordinal":
+		return p.parsePlural(name)
+	case "select":
+		return p.parseSelect(name)
+	default:
+		return nil, fmt.Errorf("messageformat: unsupported argument type %q", kind)
+	}
+}
+
+func (p *messageParser) parsePlural(name string) (Node, error) {
+	node := PluralNode{Name: name, Options: map[string]Message{}}
+	p.skipSpace()
+	if p.hasPrefix("offset:") {
+		p.pos += len("offset:")
+		p.skipSpace()
+		numTok := p.parseToken()
+		n, err := strconv.Atoi(numTok)
+		if err != nil {
+			return nil, fmt.Errorf("messageformat: invalid offset %q for %q", numTok, name)
+		}
+		node.Offset = n
+		p.skipSpace()
+	}
+	for {
+		p.skipSpace()
+		ch, ok := p.peek()
+		if !ok {
+			return nil, fmt.Errorf("messageformat: unterminated plural argument %q", name)
+		}
+		if ch == '}' {
+			p.pos++
+			break
+		}
+		key := p.parseToken()
+		p.skipSpace()
+		ch, ok = p.peek()
+		if !ok || ch != '{' {
+			return nil, fmt.Errorf("messageformat: expected '{' for plural option %q of %q", key, name)
+		}
+		p.pos++
+		sub, err := p.parseNodes(true)
+		if err != nil {
+			return nil, err
+		}
+		node.Options[key] = Message(sub)
+	}
+	if _, ok := node.Options["other"]; !ok {
+		return nil, fmt.Errorf("messageformat: plural argument %q is missing the required 'other' option", name)
+	}
+	return node, nil
+}
+
+func (p *messageParser) parseSelect(name string) (Node, error) {
+	node := SelectNode{Name: name, Options: map[string]Message{}}
+	for {
+		p.skipSpace()
+		ch, ok := p.peek()
+		if !ok {
+			return nil, fmt.Errorf("messageformat: unterminated select argument %q", name)
+		}
+		if ch == '}' {
+			p.pos++
+			break
+		}
+		key := p.parseToken()
+		p.skipSpace()
+		ch, ok = p.peek()
+		if !ok || ch != '{' {
+			return nil, fmt.Errorf("messageformat: expected '{' for select option %q of %q", key, name)
+		}
+		p.pos++
+		sub, err := p.parseNodes(true)
+		if err != nil {
+			return nil, err
+		}
+		node.Options[key] = Message(sub)
+	}
+	if _, ok := node.Options["other"]; !ok {
+		return nil, fmt.Errorf("messageformat: select argument %q is missing the required 'other' option", name)
+	}
+	return node, nil
+}
+
+// messageCache memoizes successfully parsed messages, keyed by source text, so
+// repeated requests for the same key don't re-parse its ICU message every time.
+var messageCache sync.Map // map[string]Message
+
+func parseMessageCached(raw string) (Message, error) {
+	if cached, ok := messageCache.Load(raw); ok {
+		return cached.(Message), nil
+	}
+	msg, err := ParseMessage(raw)
+	if err != nil {
+		return nil, err
+	}
+	messageCache.Store(raw, msg)
+	return msg, nil
+}
+
+// renderMessage evaluates a parsed message against lang and params, substituting
+// arg/plural/select nodes. Any argument referenced but absent from params is
+// reported in missing so the caller can decide whether to fall back to the raw
+// message for client-side resolution.
+func renderMessage(msg Message, lang string, params map[string]interface{}) (string, []string) {
+	var missing []string
+	var sb strings.Builder
+	renderNodes(msg, lang, params, &sb, &missing)
+	return sb.String(), missing
+}
+
+func renderNodes(nodes []Node, lang string, params map[string]interface{}, sb *strings.Builder, missing *[]string) {
+	for _, n := range nodes {
+		switch v := n.(type) {
+		case TextNode:
+			sb.WriteString(v.Text)
+		case ArgNode:
+			val, ok := params[v.Name]
+			if !ok {
+				*missing = append(*missing, v.Name)
+				continue
+			}
+			fmt.Fprintf(sb, "%v", val)
+		case PluralNode:
+			raw, ok := params[v.Name]
+			if !ok {
+				*missing = append(*missing, v.Name)
+				continue
+			}
+			n64 := toFloat(raw)
+			offsetN := n64 - float64(v.Offset)
+			sub, ok := v.Options["="+formatNumber(n64)]
+			if !ok {
+				sub, ok = v.Options[string(pluralCategory(lang, offsetN))]
+				if !ok {
+					sub = v.Options["other"]
+				}
+			}
+			renderPluralSub(sub, offsetN, lang, params, sb, missing)
+		case SelectNode:
+			raw, ok := params[v.Name]
+			if !ok {
+				*missing = append(*missing, v.Name)
+				continue
+			}
+			key := fmt.Sprintf("%v", raw)
+			sub, ok := v.Options[key]
+			if !ok {
+				sub = v.Options["other"]
+			}
+			renderNodes(sub, lang, params, sb, missing)
+		}
+	}
+}
+
+// renderPluralSub renders a plural option's sub-message, substituting bare '#'
+// runs with the (offset-adjusted) number, as ICU MessageFormat specifies.
+func renderPluralSub(nodes []Node, n float64, lang string, params map[string]interface{}, sb *strings.Builder, missing *[]string) {
+	for _, node := range nodes {
+		if t, ok := node.(TextNode); ok && strings.Contains(t.Text, "#") {
+			sb.WriteString(strings.ReplaceAll(t.Text, "#", formatNumber(n)))
+			continue
+		}
+		renderNodes([]Node{node}, lang, params, sb, missing)
+	}
+}
+
+func formatNumber(n float64) string {
+	if n == float64(int64(n)) {
+		return strconv.FormatInt(int64(n), 10)
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case string:
+		f, _ := strconv.ParseFloat(n, 64)
+		return f
+	default:
+		return 0
+	}
+}
+
+// resolveLocalizedValue parses raw as an ICU MessageFormat message and, if every
+// argument it references is present in params, renders it for lang. If raw isn't
+// a well-formed ICU message it is returned unchanged (plain strings are valid,
+// argument-free messages). If required arguments are missing, raw is returned
+// unchanged and unresolved is true, so the client can resolve it itself once it
+// has the params.
+func resolveLocalizedValue(raw, lang string, params map[string]interface{}) (value string, unresolved bool) {
+	msg, err := parseMessageCached(raw)
+	if err != nil {
+		return raw, false
+	}
+	for _, name := range msg.RequiredVars() {
+		if _, ok := params[name]; !ok {
+			return raw, true
+		}
+	}
+	rendered, missing := renderMessage(msg, lang, params)
+	if len(missing) > 0 {
+		return raw, true
+	}
+	return rendered, false
+}
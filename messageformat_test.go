@@ -0,0 +1,121 @@
+package main
+
+import "testing"
+
+func TestParseMessageEscaping(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		want   string
+	}{
+		{"escaped braces", "Use '{literal}' braces", "Use {literal} braces"},
+		{"adjacent escaped braces", "Nested '{' and '}' chars", "Nested { and } chars"},
+		{"doubled quote", "it''s fine", "it's fine"},
+		{"quote with no closing delimiter", "trailing 'open", "trailing open"},
+		// A lone apostrophe always opens a quoted literal run in ICU
+		// MessageFormat - to get a literal apostrophe outside one it must be
+		// doubled ("don''t"), matching the doubled-quote case above.
+		{"unescaped apostrophe opens a literal run", "don't stop", "dont stop"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, err := ParseMessage(tc.src)
+			if err != nil {
+				t.Fatalf("ParseMessage(%q) returned error: %v", tc.src, err)
+			}
+			got, missing := renderMessage(msg, "en", nil)
+			if len(missing) != 0 {
+				t.Fatalf("renderMessage(%q) reported missing vars: %v", tc.src, missing)
+			}
+			if got != tc.want {
+				t.Errorf("renderMessage(%q) = %q, want %q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseMessageArgAndPlural(t *testing.T) {
+	msg, err := ParseMessage("{count, plural, =0 {no items} one {# item} other {# items}}")
+	if err != nil {
+		t.Fatalf("ParseMessage returned error: %v", err)
+	}
+
+	cases := []struct {
+		count float64
+		want  string
+	}{
+		{0, "no items"},
+		{1, "1 item"},
+		{5, "5 items"},
+	}
+	for _, tc := range cases {
+		got, missing := renderMessage(msg, "en", map[string]interface{}{"count": tc.count})
+		if len(missing) != 0 {
+			t.Fatalf("count=%v: unexpected missing vars %v", tc.count, missing)
+		}
+		if got != tc.want {
+			t.Errorf("count=%v: got %q, want %q", tc.count, got, tc.want)
+		}
+	}
+}
+
+func TestParseMessageSelect(t *testing.T) {
+	msg, err := ParseMessage("{gender, select, male {He} female {She} other {They}} liked this")
+	if err != nil {
+		t.Fatalf("ParseMessage returned error: %v", err)
+	}
+
+	got, missing := renderMessage(msg, "en", map[string]interface{}{"gender": "female"})
+	if len(missing) != 0 {
+		t.Fatalf("unexpected missing vars %v", missing)
+	}
+	if want := "She liked this"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	got, _ = renderMessage(msg, "en", map[string]interface{}{"gender": "nonbinary"})
+	if want := "They liked this"; got != want {
+		t.Errorf("unknown select key: got %q, want %q", got, want)
+	}
+}
+
+func TestParseMessageMissingOtherIsError(t *testing.T) {
+	_, err := ParseMessage("{count, plural, one {# item}}")
+	if err == nil {
+		t.Fatal("expected an error for a plural argument missing the required 'other' option")
+	}
+}
+
+func TestParseMessageUnterminatedArgIsError(t *testing.T) {
+	_, err := ParseMessage("hello {name")
+	if err == nil {
+		t.Fatal("expected an error for an unterminated argument")
+	}
+}
+
+func TestResolveLocalizedValueMissingParam(t *testing.T) {
+	value, unresolved := resolveLocalizedValue("{count, plural, one {# item} other {# items}}", "en", nil)
+	if !unresolved {
+		t.Fatal("expected unresolved=true when the referenced param is absent")
+	}
+	if value != "{count, plural, one {# item} other {# items}}" {
+		t.Errorf("expected the raw message back unchanged, got %q", value)
+	}
+}
+
+func TestRequiredVars(t *testing.T) {
+	msg, err := ParseMessage("{greeting} {count, plural, one {# item} other {# items}}")
+	if err != nil {
+		t.Fatalf("ParseMessage returned error: %v", err)
+	}
+	got := msg.RequiredVars()
+	want := []string{"greeting", "count"}
+	if len(got) != len(want) {
+		t.Fatalf("RequiredVars() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("RequiredVars()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
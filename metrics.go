@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Component render cache metrics, exposed on GET /metrics alongside the
+// default Go/process collectors promauto registers them with.
+var (
+	componentCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "l10n_component_cache_hits_total",
+		Help: "Component requests served from a fresh cache entry.",
+	})
+
+	componentCacheStaleHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "l10n_component_cache_stale_hits_total",
+		Help: "Component requests served from a stale cache entry while a background refresh ran.",
+	})
+
+	componentCoalescedCalls = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "l10n_component_render_coalesced_total",
+		Help: "Component renders that joined an in-flight singleflight call instead of triggering their own.",
+	})
+
+	componentRefreshErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "l10n_component_render_errors_total",
+		Help: "Component render or background refresh attempts that failed.",
+	})
+)
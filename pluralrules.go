@@ -0,0 +1,131 @@
+package main
+
+// PluralCategory is one of the CLDR cardinal plural categories.
+type PluralCategory string
+
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// pluralRuleFunc maps a cardinal number to its CLDR plural category for one language.
+type pluralRuleFunc func(n float64) PluralCategory
+
+// pluralRules holds the CLDR cardinal rule for each language we support explicitly.
+// Languages not listed here fall back to English rules in pluralCategory.
+var pluralRules = map[string]pluralRuleFunc{
+	"en": pluralRuleEn,
+	"es": pluralRuleEs,
+	"fr": pluralRuleFr,
+	"de": pluralRuleDe,
+	"ru": pluralRuleRu,
+	"ar": pluralRuleAr,
+	"pl": pluralRulePl,
+}
+
+// pluralCategory resolves the CLDR plural category for n in lang, falling back to
+// English rules for unsupported languages.
+func pluralCategory(lang string, n float64) PluralCategory {
+	if rule, ok := pluralRules[lang]; ok {
+		return rule(n)
+	}
+	return pluralRuleEn(n)
+}
+
+func pluralRuleEn(n float64) PluralCategory {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralRuleEs(n float64) PluralCategory {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralRuleFr(n float64) PluralCategory {
+	if n == 0 || n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralRuleDe(n float64) PluralCategory {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRuleRu implements the CLDR rule for Russian: v = 0 and i % 10 = 1 and i % 100 != 11 => one;
+// v = 0 and i % 10 = 2..4 and i % 100 != 12..14 => few; v = 0 and i % 10 = 0, or i % 10 = 5..9,
+// or i % 100 = 11..14 => many; otherwise => other.
+func pluralRuleRu(n float64) PluralCategory {
+	if n != float64(int64(n)) {
+		return PluralOther
+	}
+	i := int64(n)
+	mod10 := i % 10
+	mod100 := i % 100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	case mod10 == 0 || (mod10 >= 5 && mod10 <= 9) || (mod100 >= 11 && mod100 <= 14):
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+// pluralRuleAr implements the CLDR rule for Arabic, which distinguishes zero/one/two
+// in addition to few/many/other.
+func pluralRuleAr(n float64) PluralCategory {
+	if n != float64(int64(n)) {
+		return PluralOther
+	}
+	i := int64(n)
+	mod100 := i % 100
+	switch {
+	case i == 0:
+		return PluralZero
+	case i == 1:
+		return PluralOne
+	case i == 2:
+		return PluralTwo
+	case mod100 >= 3 && mod100 <= 10:
+		return PluralFew
+	case mod100 >= 11 && mod100 <= 99:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+// pluralRulePl implements the CLDR rule for Polish: i = 1 and v = 0 => one;
+// v = 0 and i % 10 = 2..4 and i % 100 != 12..14 => few; any other integer =>
+// many; non-integers => other.
+func pluralRulePl(n float64) PluralCategory {
+	if n != float64(int64(n)) {
+		return PluralOther
+	}
+	i := int64(n)
+	mod10 := i % 10
+	mod100 := i % 100
+	switch {
+	case i == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+func TestPluralCategory(t *testing.T) {
+	cases := []struct {
+		lang string
+		n    float64
+		want PluralCategory
+	}{
+		// English: one/other only.
+		{"en", 1, PluralOne},
+		{"en", 0, PluralOther},
+		{"en", 2, PluralOther},
+
+		// Spanish and German follow the same one/other split as English.
+		{"es", 1, PluralOne},
+		{"es", 2, PluralOther},
+		{"de", 1, PluralOne},
+		{"de", 0, PluralOther},
+
+		// French treats 0 and 1 as "one".
+		{"fr", 0, PluralOne},
+		{"fr", 1, PluralOne},
+		{"fr", 2, PluralOther},
+
+		// Russian: one/few/many/other based on the last one/two digits.
+		{"ru", 1, PluralOne},
+		{"ru", 21, PluralOne},
+		{"ru", 11, PluralMany},
+		{"ru", 2, PluralFew},
+		{"ru", 22, PluralFew},
+		{"ru", 12, PluralMany},
+		{"ru", 5, PluralMany},
+		{"ru", 0, PluralMany},
+		{"ru", 1.5, PluralOther},
+
+		// Arabic has dedicated zero/one/two categories.
+		{"ar", 0, PluralZero},
+		{"ar", 1, PluralOne},
+		{"ar", 2, PluralTwo},
+		{"ar", 5, PluralFew},
+		{"ar", 11, PluralMany},
+		{"ar", 100, PluralOther},
+
+		// Polish: one only for exactly 1, few for small trailing digits.
+		{"pl", 1, PluralOne},
+		{"pl", 2, PluralFew},
+		{"pl", 22, PluralFew},
+		{"pl", 12, PluralMany},
+		{"pl", 5, PluralMany},
+
+		// Unsupported language falls back to English rules.
+		{"xx", 1, PluralOne},
+		{"xx", 2, PluralOther},
+	}
+
+	for _, tc := range cases {
+		if got := pluralCategory(tc.lang, tc.n); got != tc.want {
+			t.Errorf("pluralCategory(%q, %v) = %v, want %v", tc.lang, tc.n, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gin-gonic/gin"
+)
+
+// TemplateSource describes where a ComponentTemplate was loaded from, for the
+// GET /api/templates registry listing.
+type TemplateSource struct {
+	ComponentType string    `json:"component_type"`
+	SourcePath    string    `json:"source_path"`
+	LastModified  time.Time `json:"last_modified"`
+}
+
+// TemplateProvider supplies the live set of component templates. Load reads
+// the current templates from their source; Watch starts reporting changes by
+// calling onChange (providers with no notion of change, like the built-in
+// one, may implement Watch as a no-op).
+type TemplateProvider interface {
+	Load() (map[string]ComponentTemplate, map[string]TemplateSource, error)
+	Watch(onChange func())
+}
+
+// templateRegistry holds the live, swappable set of component templates.
+// Reloads replace the maps wholesale under the write lock, so readers never
+// observe a partially-updated set, and changed component types have their
+// cache entries invalidated.
+type templateRegistry struct {
+	mu        sync.RWMutex
+	templates map[string]ComponentTemplate
+	sources   map[string]TemplateSource
+	provider  TemplateProvider
+}
+
+// globalTemplateRegistry is the process-wide template registry. It's backed
+// by the built-in templates until main() loads it - directly, or by swapping
+// in a filesystem/git provider if TEMPLATES_DIR/TEMPLATES_GIT_DIR is
+// configured - which keeps the initial load out of package-var
+// initialization (it reaches back into this same registry via
+// evictComponentCache, which would otherwise be an initialization cycle).
+var globalTemplateRegistry = newTemplateRegistry(builtinTemplateProvider{})
+
+func newTemplateRegistry(provider TemplateProvider) *templateRegistry {
+	return &templateRegistry{
+		provider:  provider,
+		templates: map[string]ComponentTemplate{},
+		sources:   map[string]TemplateSource{},
+	}
+}
+
+// SetProvider swaps in a new TemplateProvider and loads it immediately.
+func (r *templateRegistry) SetProvider(provider TemplateProvider) error {
+	r.provider = provider
+	return r.Reload()
+}
+
+// Reload re-fetches templates from the current provider and swaps them in
+// atomically, then invalidates the cache for every component type whose
+// template actually changed.
+func (r *templateRegistry) Reload() error {
+	templates, sources, err := r.provider.Load()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	previous := r.templates
+	r.templates = templates
+	r.sources = sources
+	r.mu.Unlock()
+
+	for componentType, tmpl := range templates {
+		if old, ok := previous[componentType]; !ok || !templatesEqual(old, tmpl) {
+			evictComponentCache(componentType)
+		}
+	}
+	for componentType := range previous {
+		if _, ok := templates[componentType]; !ok {
+			// componentType was removed entirely (file deleted, dropped from
+			// a git pull) - its cache entries would otherwise linger until
+			// TTL/staleUntil even though a fresh lookup now 404s.
+			evictComponentCache(componentType)
+		}
+	}
+	return nil
+}
+
+// StartWatching asks the current provider to watch for changes, reloading
+// whenever it reports one.
+func (r *templateRegistry) StartWatching() {
+	r.provider.Watch(func() {
+		if err := r.Reload(); err != nil {
+			fmt.Printf("warning: templates: reload failed: %v\n", err)
+		}
+	})
+}
+
+// Templates returns a copy of the current component-type -> template map.
+func (r *templateRegistry) Templates() map[string]ComponentTemplate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string]ComponentTemplate, len(r.templates))
+	for k, v := range r.templates {
+		out[k] = v
+	}
+	return out
+}
+
+// Get looks up a single component's template.
+func (r *templateRegistry) Get(componentType string) (ComponentTemplate, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tmpl, ok := r.templates[componentType]
+	return tmpl, ok
+}
+
+// Sources returns a copy of the current component-type -> source map.
+func (r *templateRegistry) Sources() map[string]TemplateSource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string]TemplateSource, len(r.sources))
+	for k, v := range r.sources {
+		out[k] = v
+	}
+	return out
+}
+
+func templatesEqual(a, b ComponentTemplate) bool {
+	if a.ComponentName != b.ComponentName || a.ComponentType != b.ComponentType || a.Template != b.Template {
+		return false
+	}
+	if len(a.RequiredKeys) != len(b.RequiredKeys) {
+		return false
+	}
+	for i := range a.RequiredKeys {
+		if a.RequiredKeys[i] != b.RequiredKeys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// evictComponentCache drops every cached entry (TTL + Redis) for
+// componentType, across all languages and param variants. Used whenever a
+// component's template changes so stale renders don't linger until CacheTTL
+// expires.
+func evictComponentCache(componentType string) {
+	prefix := fmt.Sprintf("component:%s:", componentType)
+	componentCache.EvictWhere(func(cacheKey string, _ interface{}) bool {
+		return strings.HasPrefix(cacheKey, prefix)
+	})
+
+	// Push the reloaded template to any live /ws subscribers, in every
+	// language since a template change isn't language-specific.
+	for _, lang := range getLanguageKeys() {
+		broadcastComponentUpdate(componentType, lang)
+	}
+
+	if redisClient == nil {
+		return
+	}
+	deleteRedisKeysByPattern(prefix + "*")
+}
+
+// builtinTemplateProvider serves the templates compiled into the binary. It's
+// the default when TEMPLATES_DIR/TEMPLATES_GIT_DIR aren't configured, and
+// never calls onChange since the template set can't change at runtime.
+type builtinTemplateProvider struct{}
+
+func (builtinTemplateProvider) Load() (map[string]ComponentTemplate, map[string]TemplateSource, error) {
+	templates := make(map[string]ComponentTemplate, len(builtinComponentTemplates))
+	sources := make(map[string]TemplateSource, len(builtinComponentTemplates))
+	loadedAt := time.Now()
+	for componentType, tmpl := range builtinComponentTemplates {
+		templates[componentType] = tmpl
+		sources[componentType] = TemplateSource{
+			ComponentType: componentType,
+			SourcePath:    "<builtin>",
+			LastModified:  loadedAt,
+		}
+	}
+	return templates, sources, nil
+}
+
+func (builtinTemplateProvider) Watch(onChange func()) {}
+
+// templateMeta is the shape of each component's "<type>.meta.json" file.
+type templateMeta struct {
+	ComponentName string   `json:"component_name"`
+	ComponentType string   `json:"component_type"`
+	RequiredKeys  []string `json:"required_keys"`
+}
+
+// filesystemTemplateProvider loads component templates from a directory
+// containing, per component, a "<type>.tmpl.jsx" file holding the JSX
+// template and a "<type>.meta.json" file holding its metadata.
+type filesystemTemplateProvider struct {
+	dir string
+}
+
+func newFilesystemTemplateProvider(dir string) *filesystemTemplateProvider {
+	return &filesystemTemplateProvider{dir: dir}
+}
+
+func (p *filesystemTemplateProvider) Load() (map[string]ComponentTemplate, map[string]TemplateSource, error) {
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("templates: reading %s: %w", p.dir, err)
+	}
+
+	templates := make(map[string]ComponentTemplate)
+	sources := make(map[string]TemplateSource)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tmpl.jsx") {
+			continue
+		}
+		componentType := strings.TrimSuffix(entry.Name(), ".tmpl.jsx")
+
+		tmplPath := filepath.Join(p.dir, entry.Name())
+		metaPath := filepath.Join(p.dir, componentType+".meta.json")
+
+		tmplBytes, err := os.ReadFile(tmplPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("templates: reading %s: %w", tmplPath, err)
+		}
+		metaBytes, err := os.ReadFile(metaPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("templates: reading %s: %w", metaPath, err)
+		}
+
+		var meta templateMeta
+		if err := json.Unmarshal(metaBytes, &meta); err != nil {
+			return nil, nil, fmt.Errorf("templates: parsing %s: %w", metaPath, err)
+		}
+
+		info, err := os.Stat(tmplPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("templates: stat %s: %w", tmplPath, err)
+		}
+
+		templates[componentType] = ComponentTemplate{
+			ComponentName: meta.ComponentName,
+			ComponentType: meta.ComponentType,
+			Template:      string(tmplBytes),
+			RequiredKeys:  meta.RequiredKeys,
+		}
+		sources[componentType] = TemplateSource{
+			ComponentType: componentType,
+			SourcePath:    tmplPath,
+			LastModified:  info.ModTime(),
+		}
+	}
+
+	return templates, sources, nil
+}
+
+// Watch uses fsnotify to reload whenever a template or metadata file in the
+// directory is created, written, removed or renamed.
+func (p *filesystemTemplateProvider) Watch(onChange func()) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		fmt.Printf("warning: templates: could not start fsnotify watcher: %v\n", err)
+		return
+	}
+	if err := watcher.Add(p.dir); err != nil {
+		fmt.Printf("warning: templates: could not watch %s: %v\n", p.dir, err)
+		watcher.Close()
+		return
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !strings.HasSuffix(event.Name, ".tmpl.jsx") && !strings.HasSuffix(event.Name, ".meta.json") {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+					continue
+				}
+				onChange()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				fmt.Printf("warning: templates: watcher error: %v\n", err)
+			}
+		}
+	}()
+}
+
+// gitTemplateProvider periodically pulls a git repository checked out at dir
+// and serves templates from it via an embedded filesystemTemplateProvider, so
+// designers can push template changes without a rebuild or redeploy.
+type gitTemplateProvider struct {
+	*filesystemTemplateProvider
+	pullInterval time.Duration
+}
+
+func newGitTemplateProvider(dir string, pullInterval time.Duration) *gitTemplateProvider {
+	return &gitTemplateProvider{
+		filesystemTemplateProvider: newFilesystemTemplateProvider(dir),
+		pullInterval:               pullInterval,
+	}
+}
+
+// Watch inherits the fsnotify watch for local edits, and additionally pulls
+// the repo on a fixed interval, triggering onChange whenever the pull moves
+// HEAD.
+func (p *gitTemplateProvider) Watch(onChange func()) {
+	p.filesystemTemplateProvider.Watch(onChange)
+
+	go func() {
+		ticker := time.NewTicker(p.pullInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			before, _ := p.headCommit()
+			cmd := exec.Command("git", "-C", p.dir, "pull", "--ff-only")
+			if output, err := cmd.CombinedOutput(); err != nil {
+				fmt.Printf("warning: templates: git pull failed: %v\n%s\n", err, output)
+				continue
+			}
+			after, _ := p.headCommit()
+			if after != "" && after != before {
+				onChange()
+			}
+		}
+	}()
+}
+
+func (p *gitTemplateProvider) headCommit() (string, error) {
+	cmd := exec.Command("git", "-C", p.dir, "rev-parse", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// reloadTemplatesEndpoint forces an immediate reload from the configured
+// TemplateProvider, for designers who don't want to wait for the fsnotify/git
+// poll cycle.
+func reloadTemplatesEndpoint(c *gin.Context) {
+	if err := globalTemplateRegistry.Reload(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"reloaded": true, "templates": globalTemplateRegistry.Sources()})
+}
+
+// listTemplatesEndpoint returns the current template registry: every
+// component type, along with where it was loaded from and when.
+func listTemplatesEndpoint(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"templates": globalTemplateRegistry.Sources()})
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// invalidationChannel is the Redis pub/sub channel every replica subscribes
+// to at startup so translation mutations propagate without waiting for
+// CacheTTL/RedisTTL to expire.
+const invalidationChannel = "l10n:invalidate"
+
+// translationsHashPrefix namespaces the Redis hash that backs each
+// language's translations, one hash per language (field = key, value = text).
+const translationsHashPrefix = "l10n:translations:"
+
+func redisTranslationsHashKey(lang string) string {
+	return translationsHashPrefix + lang
+}
+
+// invalidationMessage is published on invalidationChannel whenever a
+// translation changes.
+type invalidationMessage struct {
+	Lang string `json:"lang"`
+	Key  string `json:"key"`
+}
+
+// putTranslationRequest is the body for PUT /api/translations/:lang/:key.
+type putTranslationRequest struct {
+	Value string `json:"value" binding:"required"`
+}
+
+// putTranslationEndpoint creates or updates a single translation. It writes
+// through to the in-memory store and the Redis hash, then publishes an
+// invalidation so every replica's component caches stay coherent.
+func putTranslationEndpoint(c *gin.Context) {
+	lang := c.Param("lang")
+	key := c.Param("key")
+
+	var req putTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	setTranslation(lang, key, req.Value)
+	globalKeyIndex.Rebuild()
+
+	if redisClient != nil {
+		if err := redisClient.HSet(ctx, redisTranslationsHashKey(lang), key, req.Value).Err(); err != nil {
+			fmt.Printf("warning: failed to write translation %s/%s to redis: %v\n", lang, key, err)
+		}
+	}
+
+	publishInvalidation(lang, key)
+
+	c.JSON(http.StatusOK, gin.H{"lang": lang, "key": key, "value": req.Value})
+}
+
+// deleteTranslationEndpoint removes a single translation and propagates the
+// invalidation the same way putTranslationEndpoint does.
+func deleteTranslationEndpoint(c *gin.Context) {
+	lang := c.Param("lang")
+	key := c.Param("key")
+
+	if !deleteTranslation(lang, key) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": fmt.Sprintf("translation '%s' not found for language '%s'", key, lang),
+		})
+		return
+	}
+	globalKeyIndex.Rebuild()
+
+	if redisClient != nil {
+		if err := redisClient.HDel(ctx, redisTranslationsHashKey(lang), key).Err(); err != nil {
+			fmt.Printf("warning: failed to delete translation %s/%s from redis: %v\n", lang, key, err)
+		}
+	}
+
+	publishInvalidation(lang, key)
+
+	c.JSON(http.StatusOK, gin.H{"lang": lang, "key": key, "deleted": true})
+}
+
+// publishInvalidation announces that (lang, key) changed. With Redis
+// available this fans out to every replica; without it, it just evicts this
+// process's own caches so a single-node deployment stays coherent.
+func publishInvalidation(lang, key string) {
+	if redisClient == nil {
+		evictCachesFor(lang, key)
+		return
+	}
+
+	data, err := json.Marshal(invalidationMessage{Lang: lang, Key: key})
+	if err != nil {
+		return
+	}
+	if err := redisClient.Publish(ctx, invalidationChannel, data).Err(); err != nil {
+		fmt.Printf("warning: failed to publish invalidation for %s/%s: %v\n", lang, key, err)
+	}
+}
+
+// subscribeInvalidations subscribes to invalidationChannel and evicts this
+// process's caches as messages arrive. It's called once at startup.
+func subscribeInvalidations(client *redis.Client) {
+	if client == nil {
+		return
+	}
+	sub := client.Subscribe(ctx, invalidationChannel)
+	go func() {
+		for msg := range sub.Channel() {
+			var payload invalidationMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &payload); err != nil {
+				continue
+			}
+			evictCachesFor(payload.Lang, payload.Key)
+		}
+	}()
+}
+
+// evictCachesFor drops every TTL cache entry whose RequiredKeys include key
+// for lang, plus the matching Redis component cache entries.
+func evictCachesFor(lang, key string) {
+	componentCache.EvictWhere(func(_ string, value interface{}) bool {
+		component, ok := value.(*LocalizedComponent)
+		if !ok || component.Language != lang {
+			return false
+		}
+		for _, required := range component.Metadata.RequiredKeys {
+			if required == key {
+				return true
+			}
+		}
+		return false
+	})
+
+	for componentType, template := range globalTemplateRegistry.Templates() {
+		if !requiredKeysContain(template.RequiredKeys, key) {
+			continue
+		}
+
+		// Push the affected component's new value to any live /ws subscribers.
+		broadcastComponentUpdate(componentType, lang)
+
+		if redisClient == nil {
+			continue
+		}
+		deleteRedisKeysByPattern(fmt.Sprintf("component:%s:%s*", componentType, lang))
+	}
+}
+
+func requiredKeysContain(keys []string, target string) bool {
+	for _, k := range keys {
+		if k == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package main
+
+import "testing"
+
+// withTemplateRegistry swaps globalTemplateRegistry for a fresh one seeded
+// with a single template, runs fn, then restores the original - evictCachesFor
+// reaches through the package-level registry, so this is the only way to
+// control its RequiredKeys without touching the real built-in templates.
+func withTemplateRegistry(t *testing.T, template ComponentTemplate, fn func()) {
+	t.Helper()
+	previous := globalTemplateRegistry
+	registry := newTemplateRegistry(builtinTemplateProvider{})
+	registry.templates = map[string]ComponentTemplate{template.ComponentType: template}
+	globalTemplateRegistry = registry
+	defer func() { globalTemplateRegistry = previous }()
+	fn()
+}
+
+func TestEvictCachesForMatchesLanguageAndRequiredKey(t *testing.T) {
+	previousCache := componentCache
+	componentCache = NewTTLCache(CacheMaxSize, CacheTTL)
+	defer func() { componentCache = previousCache }()
+
+	previousRedis := redisClient
+	redisClient = nil
+	defer func() { redisClient = previousRedis }()
+
+	withTemplateRegistry(t, ComponentTemplate{
+		ComponentType: "test_widget",
+		ComponentName: "TestWidget",
+		Template:      "<div>{l10n.test_key}</div>",
+		RequiredKeys:  []string{"test_key"},
+	}, func() {
+		affected := &LocalizedComponent{
+			ComponentType: "test_widget",
+			Language:      "en",
+			Metadata:      ComponentMetadata{RequiredKeys: []string{"test_key"}},
+		}
+		wrongLang := &LocalizedComponent{
+			ComponentType: "test_widget",
+			Language:      "fr",
+			Metadata:      ComponentMetadata{RequiredKeys: []string{"test_key"}},
+		}
+		wrongKey := &LocalizedComponent{
+			ComponentType: "test_widget",
+			Language:      "en",
+			Metadata:      ComponentMetadata{RequiredKeys: []string{"other_key"}},
+		}
+
+		componentCache.Put("component:test_widget:en", affected, CacheTTL, StaleWindow)
+		componentCache.Put("component:test_widget:fr", wrongLang, CacheTTL, StaleWindow)
+		componentCache.Put("component:other_widget:en", wrongKey, CacheTTL, StaleWindow)
+
+		evictCachesFor("en", "test_key")
+
+		if _, freshness := componentCache.Get("component:test_widget:en"); freshness != cacheMiss {
+			t.Error("entry matching both language and required key should have been evicted")
+		}
+		if _, freshness := componentCache.Get("component:test_widget:fr"); freshness != cacheFresh {
+			t.Error("entry for a different language should not have been evicted")
+		}
+		if _, freshness := componentCache.Get("component:other_widget:en"); freshness != cacheFresh {
+			t.Error("entry not requiring the invalidated key should not have been evicted")
+		}
+	})
+}
+
+func TestRequiredKeysContain(t *testing.T) {
+	keys := []string{"a", "b", "c"}
+	if !requiredKeysContain(keys, "b") {
+		t.Error("expected requiredKeysContain to find an existing key")
+	}
+	if requiredKeysContain(keys, "missing") {
+		t.Error("expected requiredKeysContain to report false for an absent key")
+	}
+}
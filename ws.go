@@ -0,0 +1,306 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsSendBufferSize bounds how many pending messages a client can have
+	// queued before it's considered a slow consumer and dropped.
+	wsSendBufferSize = 32
+	wsPingInterval   = 30 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsWriteWait      = 10 * time.Second
+)
+
+// wsUpgrader is shared across all /ws connections. This is an unauthenticated
+// preview server meant to be reachable from any design-tool origin, so we
+// don't restrict CheckOrigin.
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// wsMessage is the envelope pushed to clients over /ws.
+type wsMessage struct {
+	Type      string              `json:"type"` // "snapshot", "update" or "error"
+	Topic     string              `json:"topic,omitempty"`
+	Component *LocalizedComponent `json:"component,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+// wsSubscribeRequest is what a client sends to (un)subscribe from a topic.
+type wsSubscribeRequest struct {
+	Action string `json:"action"` // "subscribe" or "unsubscribe"
+	Topic  string `json:"topic"`  // "component_type:lang"
+}
+
+// wsClient is one connected /ws client: its socket, the topics it has
+// subscribed to, and a bounded outbound buffer. A slow reader never blocks
+// the fan-out - once the buffer fills, the client is disconnected instead.
+//
+// trySend and Close both run under mu so a full-buffer drop (trySend) can
+// never race another goroutine's Close, or another trySend's drop - without
+// that, two goroutines racing a send against a close panic with "send on
+// closed channel" (readPump's error replies and Broadcast fan-out both call
+// trySend on the same client concurrently).
+type wsClient struct {
+	conn   *websocket.Conn
+	send   chan wsMessage
+	mu     sync.Mutex
+	closed bool
+	topics map[string]struct{}
+}
+
+func newWSClient(conn *websocket.Conn) *wsClient {
+	return &wsClient{
+		conn:   conn,
+		send:   make(chan wsMessage, wsSendBufferSize),
+		topics: make(map[string]struct{}),
+	}
+}
+
+// trySend enqueues msg, dropping the client if its buffer is full.
+func (c *wsClient) trySend(msg wsMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.send <- msg:
+	default:
+		c.closeLocked()
+		globalConnectionManager.Remove(c)
+	}
+}
+
+// Close closes the client's outbound channel and socket. Safe to call more
+// than once or concurrently with trySend.
+func (c *wsClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeLocked()
+}
+
+// closeLocked does the actual teardown; callers must hold mu.
+func (c *wsClient) closeLocked() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.send)
+	c.conn.Close()
+}
+
+func (c *wsClient) addTopic(topic string) {
+	c.mu.Lock()
+	c.topics[topic] = struct{}{}
+	c.mu.Unlock()
+}
+
+func (c *wsClient) removeTopic(topic string) {
+	c.mu.Lock()
+	delete(c.topics, topic)
+	c.mu.Unlock()
+}
+
+// writePump drains c.send to the socket and pings every wsPingInterval to
+// detect dead connections. It closes the socket and returns on the first
+// write error or once c.send is closed.
+func (c *wsClient) writePump() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+	for {
+		select {
+		case msg, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readPump handles subscribe/unsubscribe requests from the client until it
+// disconnects or sends something unparseable.
+func (c *wsClient) readPump(manager *ConnectionManager) {
+	defer func() {
+		manager.Remove(c)
+		c.Close()
+	}()
+
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		return nil
+	})
+
+	for {
+		var req wsSubscribeRequest
+		if err := c.conn.ReadJSON(&req); err != nil {
+			return
+		}
+		switch req.Action {
+		case "subscribe":
+			manager.Subscribe(c, req.Topic)
+			sendTopicSnapshot(c, req.Topic)
+		case "unsubscribe":
+			manager.Unsubscribe(c, req.Topic)
+		default:
+			c.trySend(wsMessage{Type: "error", Error: "unknown action: " + req.Action})
+		}
+	}
+}
+
+// ConnectionManager tracks every connected /ws client and which
+// "component_type:lang" topics each has subscribed to, so a translation or
+// template change can be fanned out to just the clients that care.
+type ConnectionManager struct {
+	mu      sync.RWMutex
+	clients map[*wsClient]struct{}
+	topics  map[string]map[*wsClient]struct{}
+}
+
+// globalConnectionManager is the process-wide registry of /ws clients.
+var globalConnectionManager = newConnectionManager()
+
+func newConnectionManager() *ConnectionManager {
+	return &ConnectionManager{
+		clients: make(map[*wsClient]struct{}),
+		topics:  make(map[string]map[*wsClient]struct{}),
+	}
+}
+
+func (m *ConnectionManager) Add(client *wsClient) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[client] = struct{}{}
+}
+
+func (m *ConnectionManager) Remove(client *wsClient) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.clients[client]; !ok {
+		return
+	}
+	delete(m.clients, client)
+	for topic := range m.topics {
+		delete(m.topics[topic], client)
+		if len(m.topics[topic]) == 0 {
+			delete(m.topics, topic)
+		}
+	}
+}
+
+func (m *ConnectionManager) Subscribe(client *wsClient, topic string) {
+	m.mu.Lock()
+	if _, ok := m.topics[topic]; !ok {
+		m.topics[topic] = make(map[*wsClient]struct{})
+	}
+	m.topics[topic][client] = struct{}{}
+	m.mu.Unlock()
+	client.addTopic(topic)
+}
+
+func (m *ConnectionManager) Unsubscribe(client *wsClient, topic string) {
+	m.mu.Lock()
+	if subs, ok := m.topics[topic]; ok {
+		delete(subs, client)
+		if len(subs) == 0 {
+			delete(m.topics, topic)
+		}
+	}
+	m.mu.Unlock()
+	client.removeTopic(topic)
+}
+
+// Broadcast pushes msg to every client subscribed to topic. A client whose
+// outbound buffer is full is disconnected rather than allowed to stall
+// delivery to everyone else.
+func (m *ConnectionManager) Broadcast(topic string, msg wsMessage) {
+	m.mu.RLock()
+	subs := m.topics[topic]
+	recipients := make([]*wsClient, 0, len(subs))
+	for client := range subs {
+		recipients = append(recipients, client)
+	}
+	m.mu.RUnlock()
+
+	for _, client := range recipients {
+		client.trySend(msg)
+	}
+}
+
+// splitTopic parses a "component_type:lang" topic string.
+func splitTopic(topic string) (componentType, lang string, ok bool) {
+	idx := strings.LastIndex(topic, ":")
+	if idx <= 0 || idx == len(topic)-1 {
+		return "", "", false
+	}
+	return topic[:idx], topic[idx+1:], true
+}
+
+// sendTopicSnapshot renders topic's current LocalizedComponent (no ICU
+// params - plural/select messages are sent unresolved for the client to
+// handle) and sends it so a newly-subscribed client can render immediately.
+func sendTopicSnapshot(client *wsClient, topic string) {
+	componentType, lang, ok := splitTopic(topic)
+	if !ok {
+		client.trySend(wsMessage{Type: "error", Topic: topic, Error: `invalid topic, expected "component_type:lang"`})
+		return
+	}
+	component, err := getLocalizedComponent(componentType, lang, nil)
+	if err != nil {
+		client.trySend(wsMessage{Type: "error", Topic: topic, Error: err.Error()})
+		return
+	}
+	client.trySend(wsMessage{Type: "snapshot", Topic: topic, Component: component})
+}
+
+// broadcastComponentUpdate regenerates componentType for lang and pushes it
+// to every /ws client subscribed to that topic. Called whenever a
+// translation or template affecting that component changes.
+func broadcastComponentUpdate(componentType, lang string) {
+	component, err := getLocalizedComponent(componentType, lang, nil)
+	if err != nil {
+		return
+	}
+	topic := componentType + ":" + lang
+	globalConnectionManager.Broadcast(topic, wsMessage{Type: "update", Topic: topic, Component: component})
+}
+
+// wsEndpoint upgrades the request to a WebSocket and starts the client's
+// read/write pumps. Clients subscribe to topics by sending
+// {"action":"subscribe","topic":"welcome:en"}.
+func wsEndpoint(c *gin.Context) {
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+
+	client := newWSClient(conn)
+	globalConnectionManager.Add(client)
+
+	go client.writePump()
+	client.readPump(globalConnectionManager)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestConnectionManagerSubscribeBroadcastRemove(t *testing.T) {
+	manager := newConnectionManager()
+
+	a := newWSClient(nil)
+	b := newWSClient(nil)
+	manager.Add(a)
+	manager.Add(b)
+	manager.Subscribe(a, "welcome:en")
+	manager.Subscribe(b, "welcome:en")
+	manager.Subscribe(b, "welcome:fr")
+
+	enMsg := wsMessage{Type: "update", Topic: "welcome:en"}
+	manager.Broadcast("welcome:en", enMsg)
+	for name, c := range map[string]*wsClient{"a": a, "b": b} {
+		select {
+		case got := <-c.send:
+			if got != enMsg {
+				t.Errorf("%s: got %+v, want %+v", name, got, enMsg)
+			}
+		default:
+			t.Errorf("%s: expected to receive the welcome:en broadcast", name)
+		}
+	}
+
+	// Only b is subscribed to welcome:fr.
+	frMsg := wsMessage{Type: "update", Topic: "welcome:fr"}
+	manager.Broadcast("welcome:fr", frMsg)
+	select {
+	case got := <-b.send:
+		if got != frMsg {
+			t.Errorf("b: got %+v, want %+v", got, frMsg)
+		}
+	default:
+		t.Error("b: expected to receive the welcome:fr broadcast")
+	}
+	select {
+	case got := <-a.send:
+		t.Errorf("a: should not have received a welcome:fr message, got %+v", got)
+	default:
+	}
+
+	// Unsubscribing stops further delivery for that topic.
+	manager.Unsubscribe(b, "welcome:fr")
+	manager.Broadcast("welcome:fr", frMsg)
+	select {
+	case got := <-b.send:
+		t.Errorf("b: should not receive a broadcast after unsubscribing, got %+v", got)
+	default:
+	}
+
+	// Removing a client drops it from every topic it was subscribed to.
+	manager.Remove(a)
+	manager.Broadcast("welcome:en", enMsg)
+	select {
+	case got := <-a.send:
+		t.Errorf("a: removed client should not receive further broadcasts, got %+v", got)
+	default:
+	}
+	select {
+	case got := <-b.send:
+		if got != enMsg {
+			t.Errorf("b: got %+v, want %+v", got, enMsg)
+		}
+	default:
+		t.Error("b: expected to still receive welcome:en broadcasts after a was removed")
+	}
+	if _, ok := manager.topics["welcome:en"][a]; ok {
+		t.Error("a should have been removed from the welcome:en subscriber set")
+	}
+}
+
+// TestWSClientConcurrentSendAndClose reproduces the race this package used to
+// have between a trySend that finds the buffer full (and so closes the
+// client itself) and a concurrent, independent trySend/Close doing the same -
+// previously a "send on closed channel" panic under -race. Nothing drains
+// client.send here, so the buffer fills fast and most of these goroutines hit
+// the close path concurrently.
+func TestWSClientConcurrentSendAndClose(t *testing.T) {
+	accepted := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		accepted <- conn
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	clientConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer clientConn.Close()
+
+	client := newWSClient(<-accepted)
+
+	var wg sync.WaitGroup
+	for i := 0; i < wsSendBufferSize*4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.trySend(wsMessage{Type: "update"})
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Close()
+		}()
+	}
+	wg.Wait()
+}